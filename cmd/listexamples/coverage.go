@@ -0,0 +1,73 @@
+// Copyright Mike Hughes 2018 (mike AT mikehughes DOT info)
+//
+// LICENSE: BSD 3-Clause License (see http://opensource.org/licenses/BSD-3-Clause)
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Intermernet/listexamples/pkg/listexamples"
+)
+
+// reportCoverage prints the example-coverage summary for pkgs, and the
+// missing items themselves when showMissing is set. It reports whether
+// coverage fell below minCoverage (a percentage, e.g. "80" or "80%"; no
+// threshold is enforced when minCoverage is empty).
+func reportCoverage(pkgs []listexamples.Package, showMissing bool, minCoverage string) (belowThreshold bool, err error) {
+	var documented, total int
+	var out strings.Builder
+	for _, p := range pkgs {
+		d, t := p.Coverage()
+		documented += d
+		total += t
+		if !showMissing {
+			continue
+		}
+		missing := p.Missing()
+		if len(missing) == 0 {
+			continue
+		}
+		fmt.Fprintf(&out, "Package %s in %s\n", p.Name, p.ImportPath)
+		for _, fn := range missing {
+			switch {
+			case fn.Name == "":
+				fmt.Fprint(&out, "\tNo package level example\n")
+			case fn.Receiver != "":
+				fmt.Fprintf(&out, "\tNo example for method %s\n", fn.Name)
+			default:
+				fmt.Fprintf(&out, "\tNo example for func %s\n", fn.Name)
+			}
+		}
+	}
+	if showMissing {
+		fmt.Print(out.String())
+	}
+
+	coverage := 100.0
+	if total > 0 {
+		coverage = 100 * float64(documented) / float64(total)
+	}
+	fmt.Printf("Coverage: %.1f%% (%d/%d)\n", coverage, documented, total)
+
+	if minCoverage == "" {
+		return false, nil
+	}
+	threshold, err := parsePercent(minCoverage)
+	if err != nil {
+		return false, err
+	}
+	return coverage < threshold, nil
+}
+
+// parsePercent parses a percentage flag value such as "80" or "80%".
+func parsePercent(s string) (float64, error) {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "%")
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("listexamples: invalid -min-coverage %q: %w", s, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,69 @@
+// Copyright Mike Hughes 2018 (mike AT mikehughes DOT info)
+//
+// LICENSE: BSD 3-Clause License (see http://opensource.org/licenses/BSD-3-Clause)
+
+package main
+
+import "testing"
+
+func TestParsePercent(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{"80", 80, false},
+		{"80%", 80, false},
+		{" 80% ", 80, false},
+		{"33.3", 33.3, false},
+		{"bogus", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parsePercent(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parsePercent(%q) = (%v, nil), want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parsePercent(%q) = (_, %v), want no error", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parsePercent(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestReportCoverage(t *testing.T) {
+	belowThreshold, err := reportCoverage(testPkgs, false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if belowThreshold {
+		t.Error("reportCoverage with no -min-coverage reported belowThreshold, want false")
+	}
+
+	// testPkgs is 1 documented of 3 total (Foo, Bar, package-level): 33.3%.
+	belowThreshold, err = reportCoverage(testPkgs, false, "50")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !belowThreshold {
+		t.Error("reportCoverage(testPkgs, false, \"50\") = false, want true (33.3% < 50%)")
+	}
+
+	belowThreshold, err = reportCoverage(testPkgs, false, "10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if belowThreshold {
+		t.Error("reportCoverage(testPkgs, false, \"10\") = true, want false (33.3% >= 10%)")
+	}
+
+	if _, err := reportCoverage(testPkgs, false, "bogus"); err == nil {
+		t.Error("reportCoverage(testPkgs, false, \"bogus\") = nil error, want one from parsePercent")
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright Mike Hughes 2018 (mike AT mikehughes DOT info)
+//
+// LICENSE: BSD 3-Clause License (see http://opensource.org/licenses/BSD-3-Clause)
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/Intermernet/listexamples/pkg/listexamples"
+)
+
+// render dispatches to the renderer named by format. tmpl is the
+// text/template source to execute and is only used when format is
+// "template".
+func render(pkgs []listexamples.Package, format, tmpl string) (string, error) {
+	switch format {
+	case "text":
+		return renderText(pkgs), nil
+	case "json":
+		return renderJSON(pkgs)
+	case "html":
+		return renderHTML(pkgs)
+	case "template":
+		if tmpl == "" {
+			return "", fmt.Errorf("listexamples: -template is required when -format=template")
+		}
+		return renderTemplate(pkgs, tmpl)
+	default:
+		return "", fmt.Errorf("listexamples: unknown -format %q: want text, json, html or template", format)
+	}
+}
+
+// renderText renders pkgs in the tool's original plain text layout.
+func renderText(pkgs []listexamples.Package) string {
+	var out strings.Builder
+	for _, p := range pkgs {
+		pkgName := fmt.Sprintf("%s in %s", p.Name, p.ImportPath)
+		fmt.Fprintf(&out, "Package %s\n", pkgName)
+		for _, fn := range p.Funcs {
+			if fn.Name == "" {
+				fmt.Fprint(&out, "\tPackage level example:\n")
+			} else {
+				fmt.Fprintf(&out, "\t%s\n", fn.Name)
+			}
+			if len(fn.Examples) != 0 {
+				for _, ex := range fn.Examples {
+					fmt.Fprintf(&out, "\t\t%s:%d:\t%s\n", ex.File, ex.Line, ex.Name)
+				}
+			} else {
+				fmt.Fprintf(&out, "\t\tNo Examples for function %s in package %s\n", fn.Name, pkgName)
+			}
+		}
+	}
+	return out.String()
+}
+
+// renderJSON renders pkgs as indented JSON.
+func renderJSON(pkgs []listexamples.Package) (string, error) {
+	b, err := json.MarshalIndent(pkgs, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("listexamples: marshaling JSON: %w", err)
+	}
+	return string(b) + "\n", nil
+}
+
+// renderTemplate executes tmpl, a text/template source, with pkgs as its
+// data.
+func renderTemplate(pkgs []listexamples.Package, tmpl string) (string, error) {
+	t, err := texttemplate.New("listexamples").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("listexamples: parsing -template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, pkgs); err != nil {
+		return "", fmt.Errorf("listexamples: executing -template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// anchor returns the id godoc/pkg.go.dev use for the collapsible "Example"
+// section documenting ex, e.g. "example-Foo" or "example-Foo_Bar".
+func anchor(ex listexamples.Example) string {
+	return "example-" + strings.TrimPrefix(ex.Name, "Example")
+}
+
+// exampleHeading returns the heading godoc/pkg.go.dev show above the
+// example's code, e.g. "Example" or "Example (Bar)" for a sub-example.
+func exampleHeading(ex listexamples.Example) string {
+	suffix := strings.TrimPrefix(ex.Name, "Example")
+	if i := strings.IndexByte(suffix, '_'); i >= 0 && i < len(suffix)-1 {
+		return "Example (" + suffix[i+1:] + ")"
+	}
+	return "Example"
+}
+
+var htmlTmpl = htmltemplate.Must(htmltemplate.New("listexamples.html").Funcs(htmltemplate.FuncMap{
+	"anchor":  anchor,
+	"heading": exampleHeading,
+}).Parse(`{{range .}}
+<section>
+<h2>{{.Name}} <small>{{.ImportPath}}</small></h2>
+{{range .Funcs}}
+<h3>{{if .Name}}{{if .Receiver}}func ({{.Receiver}}) {{end}}{{.Name}}{{else}}Package-level{{end}}</h3>
+{{range .Examples}}
+<details id="{{anchor .}}">
+<summary>{{heading .}}</summary>
+<pre>{{.Code}}</pre>
+{{if or .Output .EmptyOutput}}<pre>Output:
+{{.Output}}</pre>{{end}}
+</details>
+{{else}}
+<p>No examples.</p>
+{{end}}
+{{end}}
+</section>
+{{end}}
+`))
+
+// renderHTML renders pkgs as godoc-style collapsible "Example" sections,
+// grouped by target function/method with anchors matching pkg.go.dev
+// (e.g. "#example-Foo", "#example-Foo_Bar").
+func renderHTML(pkgs []listexamples.Package) (string, error) {
+	var buf bytes.Buffer
+	if err := htmlTmpl.Execute(&buf, pkgs); err != nil {
+		return "", fmt.Errorf("listexamples: rendering HTML: %w", err)
+	}
+	return buf.String(), nil
+}
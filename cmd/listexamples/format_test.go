@@ -0,0 +1,113 @@
+// Copyright Mike Hughes 2018 (mike AT mikehughes DOT info)
+//
+// LICENSE: BSD 3-Clause License (see http://opensource.org/licenses/BSD-3-Clause)
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Intermernet/listexamples/pkg/listexamples"
+)
+
+var testPkgs = []listexamples.Package{
+	{
+		Name:       "foo",
+		ImportPath: "example.com/foo",
+		Funcs: []listexamples.Func{
+			{
+				Name: "Foo",
+				Examples: []listexamples.Example{
+					{Name: "ExampleFoo", File: "foo.go", Line: 3, Output: "ok\n"},
+				},
+			},
+			{Name: "Bar"},
+		},
+	},
+}
+
+func TestRender(t *testing.T) {
+	for _, format := range []string{"text", "json", "html"} {
+		if _, err := render(testPkgs, format, ""); err != nil {
+			t.Errorf("render(%q) = %v, want no error", format, err)
+		}
+	}
+
+	if _, err := render(testPkgs, "template", ""); err == nil {
+		t.Error(`render(testPkgs, "template", "") = nil error, want one requiring -template`)
+	}
+	if out, err := render(testPkgs, "template", "{{len .}}"); err != nil || out != "1" {
+		t.Errorf(`render(testPkgs, "template", "{{len .}}") = (%q, %v), want ("1", nil)`, out, err)
+	}
+
+	if _, err := render(testPkgs, "bogus", ""); err == nil {
+		t.Error(`render(testPkgs, "bogus", "") = nil error, want one naming the bad format`)
+	}
+}
+
+func TestRenderTextReportsMissing(t *testing.T) {
+	out := renderText(testPkgs)
+	if !strings.Contains(out, "No Examples for function Bar") {
+		t.Errorf("renderText output missing the undocumented Bar entry:\n%s", out)
+	}
+	if !strings.Contains(out, "foo.go:3:\tExampleFoo") {
+		t.Errorf("renderText output missing ExampleFoo's location:\n%s", out)
+	}
+}
+
+func TestRenderJSONRoundTrips(t *testing.T) {
+	out, err := renderJSON(testPkgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, `"ExampleFoo"`) {
+		t.Errorf("renderJSON output missing ExampleFoo:\n%s", out)
+	}
+}
+
+func TestAnchor(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"ExampleFoo", "example-Foo"},
+		{"ExampleFoo_Bar", "example-Foo_Bar"},
+	}
+	for _, tt := range tests {
+		ex := listexamples.Example{Name: tt.name}
+		if got := anchor(ex); got != tt.want {
+			t.Errorf("anchor(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExampleHeading(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"ExampleFoo", "Example"},
+		{"ExampleFoo_bar", "Example (bar)"},
+		{"ExampleFoo_Bar", "Example (Bar)"},
+	}
+	for _, tt := range tests {
+		ex := listexamples.Example{Name: tt.name}
+		if got := exampleHeading(ex); got != tt.want {
+			t.Errorf("exampleHeading(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRenderHTMLIncludesAnchors(t *testing.T) {
+	out, err := renderHTML(testPkgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, `id="example-Foo"`) {
+		t.Errorf("renderHTML output missing the ExampleFoo anchor:\n%s", out)
+	}
+	if !strings.Contains(out, "No examples.") {
+		t.Errorf("renderHTML output missing the no-examples placeholder for Bar:\n%s", out)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright Mike Hughes 2018 (mike AT mikehughes DOT info)
+//
+// LICENSE: BSD 3-Clause License (see http://opensource.org/licenses/BSD-3-Clause)
+//
+// listexamples is a command line utility to search all Go source code in a path recursively and list any example code for
+// each function, method or package.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/Intermernet/listexamples/pkg/listexamples"
+)
+
+var (
+	play    = flag.Bool("play", false, "print each playable example as a standalone program to stdout, separated by a header comment, instead of the default report")
+	playDir = flag.String("play-dir", "", "write each playable example as a standalone program under `dir` (one file per example) instead of the default report")
+
+	format       = flag.String("format", "text", "output `format`: text, json, html or template")
+	templateText = flag.String("template", "", "text/template source to execute over the scanned packages; required when -format=template")
+
+	missing     = flag.Bool("missing", false, "list exported functions, methods and packages with no documenting example, instead of the default report")
+	minCoverage = flag.String("min-coverage", "", "exit non-zero if example coverage across the scanned packages falls below this `percent` (e.g. \"80\" or \"80%\")")
+
+	concurrency     = flag.Int("j", runtime.GOMAXPROCS(0), "scan up to `n` packages in parallel")
+	continueOnError = flag.Bool("continue-on-error", false, "keep scanning after a package fails to parse, reporting it as a warning instead of aborting")
+)
+
+func main() {
+	// Fiddly cross platform stuff for usage message.
+	ps := string(os.PathSeparator)
+	cmd, err := os.Executable()
+	if err != nil {
+		log.Fatal(err)
+	}
+	cmdSlice := strings.Split(cmd, ps)
+	cmd = cmdSlice[len(cmdSlice)-1]
+	flag.Usage = func() {
+		fmt.Printf("Usage: %s [flags] path%[2]sto%[2]ssearch%[2]s\n", cmd, ps)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	// Print usage if number of arguments is incorrect.
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+	// Search based on absolute path.
+	searchPath, err := filepath.Abs(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	pkgs, scanErrs, err := listexamples.Scan(ctx, []string{searchPath},
+		listexamples.WithConcurrency(*concurrency),
+		listexamples.WithContinueOnError(*continueOnError),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, e := range scanErrs {
+		log.Printf("warning: %s\n", e)
+	}
+
+	if *play || *playDir != "" {
+		if err := writePlayable(pkgs, *playDir); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *missing || *minCoverage != "" {
+		belowThreshold, err := reportCoverage(pkgs, *missing, *minCoverage)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if belowThreshold {
+			os.Exit(1)
+		}
+		return
+	}
+
+	out, err := render(pkgs, *format, *templateText)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Print(out)
+}
+
+// writePlayable writes the standalone program for every playable example in
+// pkgs. If dir is empty the programs are printed to stdout, each preceded by
+// a header comment naming it; otherwise each is written to its own file
+// under dir.
+func writePlayable(pkgs []listexamples.Package, dir string) error {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("listexamples: %w", err)
+		}
+	}
+	for _, p := range pkgs {
+		for _, fn := range p.Funcs {
+			for _, ex := range fn.Examples {
+				if ex.Play == "" {
+					continue // not self-contained; see go/doc.Example.Play
+				}
+				if dir == "" {
+					fmt.Printf("// ---- %s: %s ----\n%s\n", p.ImportPath, ex.Name, ex.Play)
+					continue
+				}
+				name := strings.NewReplacer("/", "_", " ", "_").Replace(p.ImportPath) + "_" + ex.Name + ".go"
+				path := filepath.Join(dir, name)
+				if err := os.WriteFile(path, []byte(ex.Play), 0o644); err != nil {
+					return fmt.Errorf("listexamples: writing %s: %w", path, err)
+				}
+				log.Printf("wrote %s\n", path)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,526 @@
+// Copyright Mike Hughes 2018 (mike AT mikehughes DOT info)
+//
+// LICENSE: BSD 3-Clause License (see http://opensource.org/licenses/BSD-3-Clause)
+
+// Package listexamples searches Go source code for example functions
+// (ExampleXxx, as recognised by `go test` and godoc) and reports, for every
+// exported function, method and package, which examples document it.
+package listexamples
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/format"
+	"go/token"
+	"log"
+	"runtime"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/tools/go/packages"
+)
+
+// Package describes the examples found for a single Go package.
+type Package struct {
+	Name       string // package name, e.g. "listexamples"
+	ImportPath string // full import path, e.g. "github.com/Intermernet/listexamples/pkg/listexamples"
+	Funcs      []Func
+}
+
+// Missing returns the exported functions and methods in p with no
+// documenting example, plus a zero-value Func (Name == "") standing for the
+// package itself if it has no package-level example.
+func (p Package) Missing() []Func {
+	var out []Func
+	hasPackageExample := false
+	for _, fn := range p.Funcs {
+		if fn.Name == "" {
+			if len(fn.Examples) > 0 {
+				hasPackageExample = true
+			}
+			continue
+		}
+		if len(fn.Examples) == 0 {
+			out = append(out, fn)
+		}
+	}
+	if !hasPackageExample {
+		out = append([]Func{{}}, out...)
+	}
+	return out
+}
+
+// Coverage reports how many of p's exported functions, methods and the
+// package itself (documented counts 1, total counts 1+len(named Funcs)) have
+// at least one documenting example.
+func (p Package) Coverage() (documented, total int) {
+	hasPackageExample := false
+	for _, fn := range p.Funcs {
+		if fn.Name == "" {
+			hasPackageExample = hasPackageExample || len(fn.Examples) > 0
+			continue
+		}
+		total++
+		if len(fn.Examples) > 0 {
+			documented++
+		}
+	}
+	total++ // the package itself
+	if hasPackageExample {
+		documented++
+	}
+	return documented, total
+}
+
+// Func describes a single exported function, method or package-level
+// identifier and the examples that document it.
+type Func struct {
+	Name     string // function or method name, empty for a package-level example
+	Receiver string // receiver type name for a method example, empty otherwise
+	Examples []Example
+}
+
+// Example describes a single ExampleXxx function, as recognised by
+// go/doc.Examples (the same extraction `go test` and godoc use).
+type Example struct {
+	Name        string // the example function's own name, e.g. "ExampleFoo_bar"
+	File        string
+	Line        int
+	Doc         string
+	Code        string // the example's body, or the whole file if the example is the file's only declaration
+	Output      string // expected output, from a trailing "Output:" comment
+	Unordered   bool   // expected output is from an "Unordered output:" comment
+	EmptyOutput bool   // an "Output:" comment with no text follows, i.e. the example expects no output
+	Play        string // a standalone, runnable program equivalent to Code; empty if the example isn't self-contained
+}
+
+// Option configures a Scan.
+type Option func(*options)
+
+type options struct {
+	concurrency     int
+	continueOnError bool
+}
+
+// WithConcurrency caps the number of packages scanned in parallel. n <= 0
+// leaves the default (GOMAXPROCS) in place.
+func WithConcurrency(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.concurrency = n
+		}
+	}
+}
+
+// WithContinueOnError controls what happens when a package fails to parse or
+// scan. The default, false, makes Scan stop and return the first such error.
+// When true, Scan instead collects every such error and keeps going,
+// returning the partial results alongside the aggregated errors.
+func WithContinueOnError(continueOnError bool) Option {
+	return func(o *options) { o.continueOnError = continueOnError }
+}
+
+// Scan recursively loads every Go package found under paths (module-aware,
+// via golang.org/x/tools/go/packages) and returns the examples documenting
+// each exported function, method and package. Each entry in paths is a
+// directory to search; defaults to the current directory if none are given.
+//
+// Packages are parsed and scanned concurrently (see WithConcurrency) and the
+// walk honors ctx: it stops and returns ctx.Err() as soon as it's canceled.
+// errs reports the scan errors encountered along the way; it is only
+// non-empty when WithContinueOnError(true) is set, in which case err is nil
+// as long as the packages themselves could be loaded.
+func Scan(ctx context.Context, paths []string, opts ...Option) (pkgs []Package, errs []error, err error) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	o := options{concurrency: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	fset := token.NewFileSet()
+	var out []Package
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return nil, errs, err
+		}
+		cfg := &packages.Config{
+			Context: ctx,
+			Mode:    packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedSyntax,
+			Dir:     path,
+			Tests:   true,
+			Fset:    fset,
+		}
+		loaded, err := packages.Load(cfg, "./...")
+		if err != nil {
+			return nil, errs, fmt.Errorf("listexamples: loading packages under %s: %w", path, err)
+		}
+		var loadErrs []error
+		packages.Visit(loaded, nil, func(p *packages.Package) {
+			for _, e := range p.Errors {
+				loadErrs = append(loadErrs, e)
+			}
+		})
+		if len(loadErrs) > 0 {
+			if !o.continueOnError {
+				return nil, errs, fmt.Errorf("listexamples: %d error(s) loading packages under %s, first: %w", len(loadErrs), path, loadErrs[0])
+			}
+			errs = append(errs, loadErrs...)
+		}
+
+		scanned, scanErrs, err := scanPackages(ctx, loaded, fset, seen, o)
+		errs = append(errs, scanErrs...)
+		if err != nil {
+			return nil, errs, err
+		}
+		for _, p := range scanned {
+			out = mergePackage(out, p)
+		}
+	}
+	return out, errs, nil
+}
+
+// scanPackages extracts examples from pkgs concurrently, up to o.concurrency
+// at a time. It returns the non-fatal per-package errors collected when
+// o.continueOnError is set; otherwise it stops at the first error.
+func scanPackages(ctx context.Context, pkgs []*packages.Package, fset *token.FileSet, seen map[string]bool, o options) ([]Package, []error, error) {
+	type job struct {
+		name       string
+		importPath string
+		pkg        *packages.Package
+	}
+
+	// Tests: true yields multiple variants of the same package sharing a
+	// PkgPath/Name: a plain one (built without test files) and, when the
+	// directory has _test.go files, one compiled for `go test` that also
+	// includes the package's own _test.go files (so in-package
+	// ExampleXxx functions are only visible on that variant). Keep
+	// whichever variant has the most files per PkgPath/Name so those
+	// examples aren't dropped.
+	byKey := make(map[string]*packages.Package)
+	var keyOrder []string
+	for _, p := range pkgs {
+		// The generated test-binary package ("<import path>.test") carries
+		// no source of its own; skip it.
+		if p.Name == "main" && strings.HasSuffix(p.PkgPath, ".test") {
+			continue
+		}
+		key := p.PkgPath + "#" + p.Name
+		if existing, ok := byKey[key]; ok {
+			if len(p.CompiledGoFiles) > len(existing.CompiledGoFiles) {
+				byKey[key] = p
+			}
+			continue
+		}
+		byKey[key] = p
+		keyOrder = append(keyOrder, key)
+	}
+
+	var jobs []job
+	for _, key := range keyOrder {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		p := byKey[key]
+		// Trim "package_test" to "package" so the in-package and external
+		// test packages for a directory are grouped together below.
+		jobs = append(jobs, job{
+			name:       strings.TrimSuffix(p.Name, "_test"),
+			importPath: strings.TrimSuffix(p.PkgPath, "_test"),
+			pkg:        p,
+		})
+	}
+
+	results := make([]Package, len(jobs))
+	ok := make([]bool, len(jobs))
+	var mu sync.Mutex
+	var errs []error
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(o.concurrency)
+	for i, j := range jobs {
+		i, j := i, j
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			funcList, err := scanFuncs(j.pkg, fset)
+			if err != nil {
+				if !o.continueOnError {
+					return err
+				}
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+				return nil
+			}
+			results[i] = Package{Name: j.name, ImportPath: j.importPath, Funcs: funcList}
+			ok[i] = true
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, errs, err
+	}
+
+	// A job that errored under continueOnError leaves no result; skip it
+	// instead of returning its zero value, which would otherwise surface
+	// as a bogus empty-named package in every caller.
+	out := results[:0]
+	for i, p := range results {
+		if ok[i] {
+			out = append(out, p)
+		}
+	}
+	return out, errs, nil
+}
+
+// scanFuncs extracts the exported functions, methods and package-level
+// examples from a loaded package.
+func scanFuncs(p *packages.Package, fset *token.FileSet) ([]Func, error) {
+	astPkg := &ast.Package{Name: p.Name, Files: make(map[string]*ast.File)}
+	for i, f := range p.Syntax {
+		astPkg.Files[p.CompiledGoFiles[i]] = f
+	}
+	if !hasExported(astPkg) {
+		log.Printf("listexamples: no exported identifiers in %s\n", p.PkgPath)
+		return nil, nil
+	}
+
+	funcs := make(map[string]*Func)
+	funcOrder := make([]string, 0)
+	funcFor := func(key string) *Func {
+		f, ok := funcs[key]
+		if !ok {
+			f = &Func{Name: key}
+			funcs[key] = f
+			funcOrder = append(funcOrder, key)
+		}
+		return f
+	}
+
+	// declByName locates the FuncDecl behind each ExampleXxx name, so its
+	// file and line can be reported alongside the go/doc-derived fields.
+	declByName := make(map[string]*ast.FuncDecl)
+	for _, f := range astPkg.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			decl, ok := n.(*ast.FuncDecl)
+			if !ok {
+				return true
+			}
+			name := decl.Name.String()
+			switch {
+			case isTest(name, "Test") || isTest(name, "Benchmark"):
+			case isExample(name):
+				declByName[name] = decl
+			default:
+				// Record the function or method even if it has no example
+				// yet, keyed the same way exampleTarget keys its examples,
+				// so a method and its example always land in one Func.
+				key, receiver := funcTarget(decl)
+				funcFor(key).Receiver = receiver
+			}
+			return true
+		})
+	}
+
+	for _, ex := range doc.Examples(p.Syntax...) {
+		name := "Example" + ex.Name
+		var file string
+		var line int
+		if decl, ok := declByName[name]; ok {
+			pos := fset.Position(decl.Pos())
+			file, line = pos.Filename, pos.Line
+		}
+		code, err := formatNode(fset, ex.Code)
+		if err != nil {
+			return nil, err
+		}
+		var play string
+		if ex.Play != nil {
+			if play, err = formatNode(fset, ex.Play); err != nil {
+				return nil, err
+			}
+		}
+		e := Example{
+			Name:        name,
+			File:        file,
+			Line:        line,
+			Doc:         ex.Doc,
+			Code:        code,
+			Output:      ex.Output,
+			Unordered:   ex.Unordered,
+			EmptyOutput: ex.EmptyOutput,
+			Play:        play,
+		}
+		key, receiver := exampleTarget(ex.Name)
+		fn := funcFor(key)
+		fn.Receiver = receiver
+		fn.Examples = append(fn.Examples, e)
+	}
+
+	out := make([]Func, 0, len(funcOrder))
+	for _, key := range funcOrder {
+		out = append(out, *funcs[key])
+	}
+	return out, nil
+}
+
+// hasExported reports whether pkg declares any exported top-level
+// identifier. Unlike ast.PackageExports, it doesn't mutate pkg's files:
+// doc.Examples (called on the same *ast.File values afterwards, to extract
+// --play source) needs unexported helper declarations left in place so it
+// can inline them into a self-contained program.
+func hasExported(pkg *ast.Package) bool {
+	for _, f := range pkg.Files {
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if ast.IsExported(d.Name.Name) {
+					return true
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						if ast.IsExported(s.Name.Name) {
+							return true
+						}
+					case *ast.ValueSpec:
+						for _, name := range s.Names {
+							if ast.IsExported(name.Name) {
+								return true
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// exampleTarget returns the function/method key an example documents, and
+// the receiver type name if the example is for a method. suffix is a
+// go/doc.Example.Name value, i.e. an example's own name with the "Example"
+// prefix already removed.
+//
+// Foo        -> "Foo", ""
+// Foo_bar     -> "Foo", ""     (sub-example, "bar" is lower-case)
+// Foo_Bar     -> "Foo.Bar", "Foo" (example for method Bar on type Foo)
+// ""          -> "", ""        (package-level example)
+func exampleTarget(suffix string) (key, receiver string) {
+	if !isSubExample(suffix) {
+		return suffix, ""
+	}
+	parts := strings.SplitN(suffix, "_", 2)
+	typeName, rest := parts[0], parts[1]
+	if isMethodExample(suffix) {
+		return typeName + "." + rest, typeName
+	}
+	return typeName, ""
+}
+
+// funcTarget returns the key a plain function or method declaration
+// registers under, and its receiver type name if any, using the same
+// "Type.Method" scheme exampleTarget derives from an example's name.
+func funcTarget(decl *ast.FuncDecl) (key, receiver string) {
+	name := decl.Name.String()
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return name, ""
+	}
+	typ := decl.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	ident, ok := typ.(*ast.Ident)
+	if !ok {
+		return name, ""
+	}
+	return ident.Name + "." + name, ident.Name
+}
+
+// formatNode renders n as standalone Go source.
+func formatNode(fset *token.FileSet, n ast.Node) (string, error) {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, n); err != nil {
+		return "", fmt.Errorf("listexamples: formatting example: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// mergePackage appends pkg to pkgs, unioning its Funcs into an existing
+// entry with the same ImportPath (the in-package and external "_test"
+// variants of a directory resolve to the same ImportPath).
+func mergePackage(pkgs []Package, pkg Package) []Package {
+	for i, p := range pkgs {
+		if p.ImportPath != pkg.ImportPath {
+			continue
+		}
+		pkgs[i].Funcs = mergeFuncs(p.Funcs, pkg.Funcs)
+		return pkgs
+	}
+	return append(pkgs, pkg)
+}
+
+// mergeFuncs unions b into a, keyed by (Name, Receiver).
+func mergeFuncs(a, b []Func) []Func {
+	index := make(map[string]int, len(a))
+	for i, f := range a {
+		index[f.Name+"#"+f.Receiver] = i
+	}
+	for _, f := range b {
+		if i, ok := index[f.Name+"#"+f.Receiver]; ok {
+			a[i].Examples = append(a[i].Examples, f.Examples...)
+			continue
+		}
+		index[f.Name+"#"+f.Receiver] = len(a)
+		a = append(a, f)
+	}
+	return a
+}
+
+// isTest tells whether name looks like a test, example, or benchmark.
+// It is a Test (say) if there is a character after Test that is not a
+// lower-case letter. (We don't want Testiness.)
+//
+// isTest taken from https://golang.org/src/go/doc/example.go
+// Copyright 2011 The Go Authors. All rights reserved.
+func isTest(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	if len(name) == len(prefix) { // "Test" is ok
+		return true
+	}
+	rune, _ := utf8.DecodeRuneInString(name[len(prefix):])
+	return !unicode.IsLower(rune)
+}
+
+// isExample checks if the name is a valid Example function name.
+func isExample(name string) bool {
+	return isTest(name, "Example")
+}
+
+// isSubExample checks if the name contains an underscore "_" character.
+func isSubExample(name string) bool {
+	nSlice := strings.Split(name, "_")
+	return len(nSlice) > 1
+}
+
+// isMethodExample checks if the Example function is for
+// a method on a type.
+func isMethodExample(name string) bool {
+	rune, _ := utf8.DecodeRuneInString(strings.Split(name, "_")[1])
+	return !unicode.IsLower(rune)
+}
@@ -0,0 +1,102 @@
+// Copyright Mike Hughes 2018 (mike AT mikehughes DOT info)
+//
+// LICENSE: BSD 3-Clause License (see http://opensource.org/licenses/BSD-3-Clause)
+
+package listexamples
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+)
+
+func TestExampleTarget(t *testing.T) {
+	tests := []struct {
+		suffix        string
+		key, receiver string
+	}{
+		{"Foo", "Foo", ""},
+		{"Foo_bar", "Foo", ""},
+		{"Foo_Bar", "Foo.Bar", "Foo"},
+	}
+	for _, tt := range tests {
+		key, receiver := exampleTarget(tt.suffix)
+		if key != tt.key || receiver != tt.receiver {
+			t.Errorf("exampleTarget(%q) = (%q, %q), want (%q, %q)", tt.suffix, key, receiver, tt.key, tt.receiver)
+		}
+	}
+}
+
+func TestFuncTarget(t *testing.T) {
+	const src = `package p
+
+func Plain() {}
+
+func (f Foo) Bar() {}
+
+func (b *Baz) Bar() {}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		key, receiver string
+	}{
+		{"Plain", "Plain", ""},
+		{"Bar", "Foo.Bar", "Foo"}, // first declaration of Bar, on Foo
+	}
+	var decls []*ast.FuncDecl
+	ast.Inspect(file, func(n ast.Node) bool {
+		if decl, ok := n.(*ast.FuncDecl); ok {
+			decls = append(decls, decl)
+		}
+		return true
+	})
+	if len(decls) != 3 {
+		t.Fatalf("got %d func decls, want 3", len(decls))
+	}
+
+	for i, tt := range tests {
+		key, receiver := funcTarget(decls[i])
+		if key != tt.key || receiver != tt.receiver {
+			t.Errorf("funcTarget(%s) = (%q, %q), want (%q, %q)", tt.name, key, receiver, tt.key, tt.receiver)
+		}
+	}
+
+	// The second Bar, on *Baz, must key separately from the first.
+	key, receiver := funcTarget(decls[2])
+	if key != "Baz.Bar" || receiver != "Baz" {
+		t.Errorf("funcTarget(Baz.Bar) = (%q, %q), want (%q, %q)", key, receiver, "Baz.Bar", "Baz")
+	}
+}
+
+// TestMissingCoverageSameMethodName reproduces the bug where two types
+// sharing a method name (Foo.Bar documented, Baz.Bar not) collapsed into a
+// single Func, so the undocumented method went unreported.
+func TestMissingCoverageSameMethodName(t *testing.T) {
+	p := Package{
+		Name:       "methods",
+		ImportPath: "toytest/pkg/methods",
+		Funcs: []Func{
+			{Name: "Foo.Bar", Receiver: "Foo", Examples: []Example{{Name: "ExampleFoo_Bar"}}},
+			{Name: "Baz.Bar", Receiver: "Baz"},
+		},
+	}
+
+	missing := p.Missing()
+	want := []Func{{}, {Name: "Baz.Bar", Receiver: "Baz"}}
+	if !reflect.DeepEqual(missing, want) {
+		t.Errorf("Missing() = %+v, want %+v", missing, want)
+	}
+
+	documented, total := p.Coverage()
+	if documented != 1 || total != 3 {
+		t.Errorf("Coverage() = (%d, %d), want (1, 3)", documented, total)
+	}
+}